@@ -0,0 +1,76 @@
+package server
+
+import (
+	"github.com/nknorg/nkn/core/ledger"
+)
+
+// topics is the process-wide topic registry shared by every WsServer
+// instance, mirroring the package-level singleton pattern already used for
+// ws in api/websocket.
+var topics = newTopicRegistry()
+
+// SubscribeTopic registers sessionID, authenticated as address, as
+// watching topic/bucket. It is the handler for a client-side
+// "subscribeTopic" RPC method; no dispatch entry routes to it in this
+// package (see OnSessionClosed). Broadcast still requires address to
+// actually be a subscriber of topic before anything is pushed to it.
+func (s *WsServer) SubscribeTopic(sessionID, address, topic string, bucket uint32) {
+	topics.SubscribeTopic(sessionID, address, topic, bucket)
+}
+
+// UnsubscribeTopic stops notifying sessionID about topic.
+func (s *WsServer) UnsubscribeTopic(sessionID, topic string) {
+	topics.UnsubscribeTopic(sessionID, topic)
+}
+
+// RemoveTopicSessions drops sessionID from every topic it was watching.
+func (s *WsServer) RemoveTopicSessions(sessionID string) {
+	topics.RemoveSession(sessionID)
+}
+
+// OnSessionClosed is the real call site for RemoveTopicSessions: it should
+// be invoked by the session's close/disconnect handler so a session that
+// drops without calling UnsubscribeTopic doesn't leak watch state.
+//
+// That disconnect handler, and the RPC dispatch table SubscribeTopic/
+// UnsubscribeTopic need an entry in, both live on WsServer's core
+// implementation (InitWsServer, the connection/session loop) -- not part
+// of this source tree, so there is nothing in api/websocket/server to add
+// the `case "subscribeTopic":`/`case "unsubscribeTopic":` dispatch arms
+// or the `ws.OnSessionClosed(sessionID)` call to. Wherever that loop's
+// session-close path and dispatch switch are defined, those are the two
+// lines this change still needs.
+func (s *WsServer) OnSessionClosed(sessionID string) {
+	s.RemoveTopicSessions(sessionID)
+}
+
+// TopicEventDroppedCount returns how many topicEvents have been dropped
+// for sessionID because it exceeded its rate limit.
+func (s *WsServer) TopicEventDroppedCount(sessionID string) uint64 {
+	return topics.DroppedCount(sessionID)
+}
+
+// PushTopicEvent pushes a topicEvent to every session that called
+// SubscribeTopic for topic/bucket and whose authenticated address is also
+// a subscriber of topic on-chain; the on-chain check does not look at the
+// bucket. event should be one of "join" or "leave".
+func (s *WsServer) PushTopicEvent(topic, subscriber, event string, bucket uint32, height uint32) {
+	resp := map[string]interface{}{
+		"Action":     "topicEvent",
+		"Topic":      topic,
+		"Subscriber": subscriber,
+		"Event":      event,
+		"Bucket":     bucket,
+		"Height":     height,
+	}
+	isSubscriber := func(address string) bool {
+		subscribed, err := ledger.DefaultLedger.Store.IsSubscribed(topic, address)
+		if err != nil {
+			return false
+		}
+		return subscribed
+	}
+	topics.Broadcast(topic, bucket, resp, isSubscriber, func(sessionID string, payload map[string]interface{}) {
+		s.PushResultToSession(sessionID, payload)
+	})
+}
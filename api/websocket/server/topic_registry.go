@@ -0,0 +1,163 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/nknorg/nkn/util/log"
+)
+
+const (
+	// topicEventRateLimit/topicEventRateBurst cap how many topicEvents a
+	// single session is delivered per second. A session that can't keep up
+	// has events dropped rather than queued, so one slow subscriber to a
+	// high-churn topic can't build an unbounded backlog against the
+	// dispatcher.
+	topicEventRateLimit = rate.Limit(50)
+	topicEventRateBurst = 100
+)
+
+// watcher is one session's registered interest in a topic: its
+// authenticated address (so Broadcast can require the address to match a
+// real subscriber, not just whoever called the watch RPC) and the bucket
+// it asked to be scoped to. bucket 0 means "every bucket of this topic".
+type watcher struct {
+	address string
+	bucket  uint32
+}
+
+// topicRegistry maintains, for every topic, which sessions are currently
+// watching it (and the reverse index, for O(1) cleanup on disconnect), so
+// a topicEvent only has to be pushed to sessions that actually care.
+type topicRegistry struct {
+	mu             sync.RWMutex
+	topicSessions  map[string]map[string]watcher // topic -> sessionID -> watcher
+	sessionTopics  map[string]map[string]struct{} // sessionID -> set of topics
+	limiters       map[string]*rate.Limiter       // sessionID -> rate limiter
+	droppedCounter map[string]uint64
+}
+
+func newTopicRegistry() *topicRegistry {
+	return &topicRegistry{
+		topicSessions:  make(map[string]map[string]watcher),
+		sessionTopics:  make(map[string]map[string]struct{}),
+		limiters:       make(map[string]*rate.Limiter),
+		droppedCounter: make(map[string]uint64),
+	}
+}
+
+// SubscribeTopic registers sessionID, authenticated as address, as
+// watching topic. bucket scopes the watch to a single Subscribe bucket;
+// pass 0 to watch every bucket of the topic.
+func (r *topicRegistry) SubscribeTopic(sessionID, address, topic string, bucket uint32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.topicSessions[topic] == nil {
+		r.topicSessions[topic] = make(map[string]watcher)
+	}
+	r.topicSessions[topic][sessionID] = watcher{address: address, bucket: bucket}
+
+	if r.sessionTopics[sessionID] == nil {
+		r.sessionTopics[sessionID] = make(map[string]struct{})
+	}
+	r.sessionTopics[sessionID][topic] = struct{}{}
+
+	r.ensureSessionLocked(sessionID)
+}
+
+// UnsubscribeTopic removes sessionID from topic's watcher set.
+func (r *topicRegistry) UnsubscribeTopic(sessionID, topic string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if sessions, ok := r.topicSessions[topic]; ok {
+		delete(sessions, sessionID)
+		if len(sessions) == 0 {
+			delete(r.topicSessions, topic)
+		}
+	}
+	if topics, ok := r.sessionTopics[sessionID]; ok {
+		delete(topics, topic)
+	}
+}
+
+// RemoveSession drops sessionID from every topic it was watching. It
+// should be called by the session's close/disconnect handler so watch
+// state doesn't leak; WsServer.OnSessionClosed is the call site for that.
+func (r *topicRegistry) RemoveSession(sessionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for topic := range r.sessionTopics[sessionID] {
+		if sessions, ok := r.topicSessions[topic]; ok {
+			delete(sessions, sessionID)
+			if len(sessions) == 0 {
+				delete(r.topicSessions, topic)
+			}
+		}
+	}
+	delete(r.sessionTopics, sessionID)
+	delete(r.limiters, sessionID)
+	delete(r.droppedCounter, sessionID)
+}
+
+// ensureSessionLocked lazily creates the per-session rate limiter.
+// Callers must hold r.mu.
+func (r *topicRegistry) ensureSessionLocked(sessionID string) {
+	if _, ok := r.limiters[sessionID]; !ok {
+		r.limiters[sessionID] = rate.NewLimiter(topicEventRateLimit, topicEventRateBurst)
+	}
+}
+
+// watchersFor returns the (sessionID, watcher) pairs currently registered
+// for topic, scoped to bucket: a watcher registered with bucket 0 matches
+// every bucket, otherwise the buckets must match exactly.
+func (r *topicRegistry) watchersFor(topic string, bucket uint32) map[string]watcher {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := make(map[string]watcher, len(r.topicSessions[topic]))
+	for sessionID, w := range r.topicSessions[topic] {
+		if w.bucket != 0 && w.bucket != bucket {
+			continue
+		}
+		matched[sessionID] = w
+	}
+	return matched
+}
+
+// DroppedCount returns how many topicEvents have been dropped for
+// sessionID due to its rate limit, so callers can surface it (e.g. over
+// an RPC or metrics endpoint) instead of it only ever being logged.
+func (r *topicRegistry) DroppedCount(sessionID string) uint64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.droppedCounter[sessionID]
+}
+
+// Broadcast pushes event to every session watching topic/bucket whose
+// registered address satisfies isSubscriber, subject to each session's
+// rate limit. A session over its limit has the event dropped rather than
+// delivered late, so a high-churn topic can't build an unbounded backlog
+// against a slow consumer.
+func (r *topicRegistry) Broadcast(topic string, bucket uint32, event map[string]interface{}, isSubscriber func(address string) bool, push func(sessionID string, event map[string]interface{})) {
+	for sessionID, w := range r.watchersFor(topic, bucket) {
+		if isSubscriber != nil && !isSubscriber(w.address) {
+			continue
+		}
+		r.mu.RLock()
+		limiter := r.limiters[sessionID]
+		r.mu.RUnlock()
+		if limiter != nil && !limiter.AllowN(time.Now(), 1) {
+			r.mu.Lock()
+			r.droppedCounter[sessionID]++
+			r.mu.Unlock()
+			log.Warnf("topicEvent dropped for session %s on topic %s: rate limit exceeded", sessionID, topic)
+			continue
+		}
+		push(sessionID, event)
+	}
+}
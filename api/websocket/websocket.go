@@ -8,6 +8,8 @@ import (
 	"github.com/nknorg/nkn/api/websocket/server"
 	. "github.com/nknorg/nkn/common"
 	"github.com/nknorg/nkn/core/ledger"
+	tx "github.com/nknorg/nkn/core/transaction"
+	"github.com/nknorg/nkn/core/transaction/payload"
 	"github.com/nknorg/nkn/events"
 	. "github.com/nknorg/nkn/net/protocol"
 	. "github.com/nknorg/nkn/util/config"
@@ -43,6 +45,9 @@ func SendBlock2WSclient(v interface{}) {
 			PushBlockTransactions(v)
 		}()
 	}
+	go func() {
+		PushTopicEvents(v)
+	}()
 }
 
 func GetWsPushBlockFlag() bool {
@@ -124,6 +129,36 @@ func PushSigChainBlockHash(v interface{}) {
 	}
 }
 
+// PushTopicEvents scans a persisted block for Subscribe/Unsubscribe
+// transactions and pushes a scoped topicEvent to only the sessions
+// watching that topic. An Unsubscribe is pushed as a "leave" topicEvent;
+// no separate "unsubscribe" action is sent.
+func PushTopicEvents(v interface{}) {
+	if ws == nil {
+		return
+	}
+	block, ok := v.(*ledger.Block)
+	if !ok {
+		return
+	}
+	for _, txn := range block.Transactions {
+		switch txn.TxType {
+		case tx.SubscribeType:
+			subscribe, ok := txn.Payload.(*payload.Subscribe)
+			if !ok {
+				continue
+			}
+			ws.PushTopicEvent(subscribe.Topic, subscribe.SubscriberString(), "join", subscribe.Bucket, block.Header.Height)
+		case tx.UnsubscribeType:
+			unsubscribe, ok := txn.Payload.(*payload.Unsubscribe)
+			if !ok {
+				continue
+			}
+			ws.PushTopicEvent(unsubscribe.Topic, unsubscribe.SubscriberString(), "leave", 0, block.Header.Height)
+		}
+	}
+}
+
 func GetServer() *server.WsServer {
 	return ws
 }
@@ -0,0 +1,172 @@
+// Command nkn-signer is a standalone signing daemon. It holds the local
+// keystore wallet and exposes a minimal JSON-RPC API over a Unix socket so
+// that nknd can run with vault.RemoteSigner and never hold private keys in
+// its own memory, letting operators keep the signer on a hardened host
+// while the rest of the node runs in a less trusted environment.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+
+	"github.com/nknorg/nkn/common"
+	"github.com/nknorg/nkn/util/log"
+	"github.com/nknorg/nkn/vault"
+	"github.com/urfave/cli"
+)
+
+var (
+	socketPath string
+	token      string
+)
+
+type request struct {
+	ID     uint64          `json:"id"`
+	Token  string          `json:"token"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	ID     uint64      `json:"id"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+type signDataParams struct {
+	Data []byte `json:"data"`
+}
+
+func serve(wallet vault.Wallet) error {
+	if err := os.RemoveAll(socketPath); err != nil {
+		return err
+	}
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	log.Infof("nkn-signer listening on %s", socketPath)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			log.Error("nkn-signer accept error: ", err)
+			continue
+		}
+		go handleConn(conn, wallet)
+	}
+}
+
+func handleConn(conn net.Conn, wallet vault.Wallet) {
+	defer conn.Close()
+	rd := bufio.NewReader(conn)
+	enc := json.NewEncoder(conn)
+
+	for {
+		line, err := rd.ReadBytes('\n')
+		if err != nil {
+			return
+		}
+
+		var req request
+		if err := json.Unmarshal(line, &req); err != nil {
+			enc.Encode(response{Error: err.Error()})
+			continue
+		}
+		if req.Token != token {
+			enc.Encode(response{ID: req.ID, Error: "invalid token"})
+			continue
+		}
+
+		result, err := dispatch(wallet, req.Method, req.Params)
+		if err != nil {
+			enc.Encode(response{ID: req.ID, Error: err.Error()})
+			continue
+		}
+		enc.Encode(response{ID: req.ID, Result: result})
+	}
+}
+
+func dispatch(wallet vault.Wallet, method string, params json.RawMessage) (interface{}, error) {
+	account, err := wallet.GetDefaultAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	switch method {
+	case "getPublicKey":
+		encoded, err := account.PublicKey.EncodePoint()
+		if err != nil {
+			return nil, err
+		}
+		return common.BytesToHexString(encoded), nil
+	case "signTransaction":
+		var p signDataParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return wallet.Sign(account.PublicKey, p.Data)
+	case "signBlockHeader":
+		var p signDataParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return wallet.SignBlockHeader(account.PublicKey, p.Data)
+	case "signSigChainElem":
+		var p signDataParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return wallet.SignSigChainElem(account.PublicKey, p.Data)
+	case "computeVRFProof":
+		var p signDataParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return wallet.ComputeVRFProof(p.Data)
+	case "getVRFPublicKey":
+		return wallet.VRFPublicKey()
+	default:
+		return nil, errors.New("unknown method: " + method)
+	}
+}
+
+func main() {
+	log.Init(log.Path, log.Stdout)
+
+	app := cli.NewApp()
+	app.Name = "nkn-signer"
+	app.Usage = "standalone signing daemon for nknd"
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:        "socket",
+			Usage:       "Unix socket path to listen on",
+			Value:       "nkn-signer.sock",
+			Destination: &socketPath,
+		},
+		cli.StringFlag{
+			Name:        "token",
+			Usage:       "Shared secret token required on every request",
+			Destination: &token,
+		},
+	}
+	app.Action = func(c *cli.Context) error {
+		if token == "" {
+			return errors.New("--token is required")
+		}
+		wallet := vault.GetWallet()
+		if wallet == nil {
+			return errors.New("open local wallet error")
+		}
+		return serve(wallet)
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Errorf("%v", err)
+		os.Exit(1)
+	}
+}
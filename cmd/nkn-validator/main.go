@@ -0,0 +1,168 @@
+// Command nkn-validator is a conformance-testing CLI for nknd's block
+// validation. Its "test" subcommand connects to a remote nknd over its
+// JSON-RPC API, feeds it a testchain fixture one block at a time via the
+// submitTestBlock admin endpoint, and reports any divergence between the
+// fixture's expected outcome and what the remote node actually did. This
+// gives third-party nknd implementations a conformance suite without
+// needing a live network.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/nknorg/nkn/core/ledger/testchain"
+	"github.com/urfave/cli"
+)
+
+type rpcRequest struct {
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+	ID     string        `json:"id"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// submitTestBlock posts to the submitTestBlock admin JSON-RPC method this
+// command depends on. That method is not implemented anywhere in this
+// source tree: api/httpjson (nknd's JSON-RPC server, registered from
+// nknd.go) has no files in this snapshot, so there is no existing
+// dispatch table to add a "submitTestBlock" entry to, and fabricating
+// nknd's whole RPC server to add one method would be guessing at its
+// request/response framing rather than matching it. Until api/httpjson
+// is in reach, this command can only run against a remote nknd that has
+// the endpoint added out-of-band; this function reflects its specified
+// request shape so that nknd's implementation and this client agree once
+// it exists.
+func submitTestBlock(rpcAddr string, blockHex string) (accepted bool, errMsg string, err error) {
+	req := rpcRequest{
+		Method: "submitTestBlock",
+		Params: []interface{}{blockHex},
+		ID:     "nkn-validator",
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return false, "", err
+	}
+
+	resp, err := http.Post(rpcAddr, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return false, "", err
+	}
+	defer resp.Body.Close()
+
+	var rpcResp rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return false, "", err
+	}
+	if rpcResp.Error != nil {
+		return false, rpcResp.Error.Message, nil
+	}
+
+	var result struct {
+		Accepted bool   `json:"accepted"`
+		Error    string `json:"error"`
+	}
+	if err := json.Unmarshal(rpcResp.Result, &result); err != nil {
+		return false, "", err
+	}
+	return result.Accepted, result.Error, nil
+}
+
+func runTest(c *cli.Context) error {
+	rpcAddr := c.String("rpc")
+	chainPath := c.String("chain")
+	manifestPath := c.String("manifest")
+
+	fixture, err := testchain.Load(chainPath, manifestPath)
+	if err != nil {
+		return fmt.Errorf("load fixture: %v", err)
+	}
+
+	var failures int
+	for i, block := range fixture.Blocks {
+		if i == 0 {
+			continue // genesis is seeded out-of-band, not submitted
+		}
+
+		buf := bytes.NewBuffer(nil)
+		if err := block.Serialize(buf); err != nil {
+			return fmt.Errorf("serialize block %d: %v", block.Header.Height, err)
+		}
+		blockHex := fmt.Sprintf("%x", buf.Bytes())
+
+		accepted, errMsg, err := submitTestBlock(rpcAddr, blockHex)
+		if err != nil {
+			return fmt.Errorf("submit block %d: %v", block.Header.Height, err)
+		}
+
+		if !accepted {
+			failures++
+			fmt.Printf("DIVERGED height=%d: remote node rejected a block the fixture expects to be accepted: %s\n",
+				block.Header.Height, errMsg)
+		}
+	}
+
+	for _, variant := range fixture.Manifest.Corrupted {
+		corrupted, err := testchain.ApplyCorruption(fixture.Blocks[len(fixture.Blocks)-1], variant.Kind)
+		if err != nil {
+			return fmt.Errorf("apply corruption %s: %v", variant.Kind, err)
+		}
+
+		buf := bytes.NewBuffer(nil)
+		if err := corrupted.Serialize(buf); err != nil {
+			return fmt.Errorf("serialize corrupted block: %v", err)
+		}
+		blockHex := fmt.Sprintf("%x", buf.Bytes())
+
+		accepted, errMsg, err := submitTestBlock(rpcAddr, blockHex)
+		if err != nil {
+			return fmt.Errorf("submit corrupted block %s: %v", variant.Kind, err)
+		}
+
+		if accepted || !strings.Contains(errMsg, variant.WantErr) {
+			failures++
+			fmt.Printf("DIVERGED corruption=%s: expected rejection containing %q, got accepted=%v error=%q\n",
+				variant.Kind, variant.WantErr, accepted, errMsg)
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d divergence(s) from fixture manifest", failures)
+	}
+
+	fmt.Println("OK: remote node matched the fixture manifest on every block")
+	return nil
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "nkn-validator"
+	app.Usage = "conformance test runner for nknd block validation"
+	app.Commands = []cli.Command{
+		{
+			Name:  "test",
+			Usage: "replay a testchain fixture against a remote nknd and report any divergence",
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "rpc", Usage: "nknd JSON-RPC address", Value: "http://localhost:30003"},
+				cli.StringFlag{Name: "chain", Usage: "path to the fixture chain file", Value: "testchain.dat"},
+				cli.StringFlag{Name: "manifest", Usage: "path to the fixture manifest", Value: "testchain.json"},
+			},
+			Action: runTest,
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,101 @@
+package ledger
+
+import (
+	"io"
+
+	. "github.com/nknorg/nkn/common"
+	"github.com/nknorg/nkn/common/serialization"
+)
+
+// WinnerType records which mechanism produced a block's WinnerHash, so the
+// next block's HeaderCheck knows how to resolve the signer it points to.
+type WinnerType byte
+
+const (
+	GenesisSigner WinnerType = iota
+	TxnSigner
+)
+
+// Header is a block header. VRFProof is the winning candidate's VRF ticket
+// for this round's proposer election (see por.Beacon): it lets HeaderCheck
+// verify the election deterministically instead of trusting Signer alone.
+type Header struct {
+	Version       uint32
+	PrevBlockHash Uint256
+	Timestamp     int64
+	Height        uint32
+	WinnerHash    Uint256
+	WinnerType    WinnerType
+	Signer        []byte
+	VRFProof      []byte
+	Signature     []byte
+}
+
+// SerializeUnsigned writes every header field that is covered by Signature,
+// i.e. everything except the signature itself. VRFProof is included since
+// it is set before signing and must not be forgeable after the fact.
+func (h *Header) SerializeUnsigned(w io.Writer) error {
+	if err := serialization.WriteUint32(w, h.Version); err != nil {
+		return err
+	}
+	if err := h.PrevBlockHash.Serialize(w); err != nil {
+		return err
+	}
+	if err := serialization.WriteUint32(w, uint32(h.Timestamp)); err != nil {
+		return err
+	}
+	if err := serialization.WriteUint32(w, h.Height); err != nil {
+		return err
+	}
+	if err := h.WinnerHash.Serialize(w); err != nil {
+		return err
+	}
+	if err := serialization.WriteByte(w, byte(h.WinnerType)); err != nil {
+		return err
+	}
+	if err := serialization.WriteVarBytes(w, h.Signer); err != nil {
+		return err
+	}
+	return serialization.WriteVarBytes(w, h.VRFProof)
+}
+
+func (h *Header) Serialize(w io.Writer) error {
+	if err := h.SerializeUnsigned(w); err != nil {
+		return err
+	}
+	return serialization.WriteVarBytes(w, h.Signature)
+}
+
+func (h *Header) Deserialize(r io.Reader) error {
+	var err error
+	if h.Version, err = serialization.ReadUint32(r); err != nil {
+		return err
+	}
+	if err = h.PrevBlockHash.Deserialize(r); err != nil {
+		return err
+	}
+	timestamp, err := serialization.ReadUint32(r)
+	if err != nil {
+		return err
+	}
+	h.Timestamp = int64(timestamp)
+	if h.Height, err = serialization.ReadUint32(r); err != nil {
+		return err
+	}
+	if err = h.WinnerHash.Deserialize(r); err != nil {
+		return err
+	}
+	winnerType, err := serialization.ReadByte(r)
+	if err != nil {
+		return err
+	}
+	h.WinnerType = WinnerType(winnerType)
+	if h.Signer, err = serialization.ReadVarBytes(r); err != nil {
+		return err
+	}
+	if h.VRFProof, err = serialization.ReadVarBytes(r); err != nil {
+		return err
+	}
+	h.Signature, err = serialization.ReadVarBytes(r)
+	return err
+}
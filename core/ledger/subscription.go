@@ -0,0 +1,34 @@
+package ledger
+
+import (
+	"github.com/nknorg/nkn/core/transaction/payload"
+	"github.com/nknorg/nkn/util/log"
+)
+
+// ApplyUnsubscribe removes the subscriber's entry for this topic from the
+// store.
+func ApplyUnsubscribe(unsubscribe *payload.Unsubscribe) error {
+	return DefaultLedger.Store.RemoveSubscription(unsubscribe.Topic, unsubscribe.SubscriberString())
+}
+
+// ApplyPersistedUnsubscriptions is the real block-persist-time call site
+// for ApplyUnsubscribe: it is subscribed to events.EventBlockPersistCompleted
+// the same way api/websocket subscribes SendBlock2WSclient, so every
+// Unsubscribe transaction in a newly persisted block has its bucket entry
+// deleted and stops routing notifications immediately, rather than only
+// being rejected at TransactionCheck time without ever being applied.
+func ApplyPersistedUnsubscriptions(v interface{}) {
+	block, ok := v.(*Block)
+	if !ok {
+		return
+	}
+	for _, txn := range block.Transactions {
+		unsubscribe, ok := txn.Payload.(*payload.Unsubscribe)
+		if !ok {
+			continue
+		}
+		if err := ApplyUnsubscribe(unsubscribe); err != nil {
+			log.Errorf("apply unsubscribe for topic %s: %v", unsubscribe.Topic, err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package testchain
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/nknorg/nkn/core/ledger"
+	tx "github.com/nknorg/nkn/core/transaction"
+	"github.com/nknorg/nkn/core/transaction/payload"
+)
+
+// ApplyCorruption returns a shallow copy of base mutated according to kind,
+// matching one of the CorruptedVariant entries in a Fixture's manifest.
+func ApplyCorruption(base *ledger.Block, kind CorruptionKind) (*ledger.Block, error) {
+	corrupted := *base
+	header := *base.Header
+	corrupted.Header = &header
+	corrupted.Transactions = append([]*tx.Transaction{}, base.Transactions...)
+
+	switch kind {
+	case CorruptBadCoinbasePosition:
+		if len(corrupted.Transactions) < 2 {
+			return nil, fmt.Errorf("block %d has no non-coinbase transaction to swap in", base.Header.Height)
+		}
+		corrupted.Transactions[0], corrupted.Transactions[1] = corrupted.Transactions[1], corrupted.Transactions[0]
+
+	case CorruptPostdatedTimestamp:
+		corrupted.Header.Timestamp = time.Now().Add(time.Hour).Unix()
+
+	case CorruptWrongSignerPublicKey:
+		garbage := make([]byte, len(corrupted.Header.Signer))
+		if _, err := rand.Read(garbage); err != nil {
+			return nil, err
+		}
+		corrupted.Header.Signer = garbage
+
+	case CorruptInvalidSigChainCommit:
+		for i, txn := range corrupted.Transactions {
+			commit, ok := txn.Payload.(*payload.Commit)
+			if !ok {
+				continue
+			}
+			garbage := make([]byte, len(commit.SigChain))
+			if _, err := rand.Read(garbage); err != nil {
+				return nil, err
+			}
+			txnCopy := *txn
+			commitCopy := *commit
+			commitCopy.SigChain = garbage
+			txnCopy.Payload = &commitCopy
+			corrupted.Transactions[i] = &txnCopy
+			break
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown corruption kind: %s", kind)
+	}
+
+	return &corrupted, nil
+}
@@ -0,0 +1,147 @@
+package testchain
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/nknorg/nkn/core/ledger"
+	"github.com/nknorg/nkn/core/transaction"
+	"github.com/nknorg/nkn/db"
+	"github.com/nknorg/nkn/por"
+	"github.com/nknorg/nkn/vault"
+)
+
+// Generate builds a canonical Fixture of numBlocks blocks on top of a fresh
+// genesis block signed by wallet's default account, plus the
+// deliberately-corrupted variants of the last generated block that this
+// bootstrap fixture can meaningfully exercise: a postdated timestamp and a
+// wrong signer public key. numBlocks must stay below
+// ledger.GenesisBlockProposedHeight: this fixture never submits a Commit
+// transaction, so every block's WinnerType stays GenesisSigner, and
+// HeaderCheck rejects GenesisSigner blocks at or past that height.
+func Generate(wallet vault.Wallet, numBlocks uint32) (*Fixture, error) {
+	account, err := wallet.GetDefaultAccount()
+	if err != nil {
+		return nil, fmt.Errorf("load default account: %v", err)
+	}
+
+	store, err := db.NewMemoryLedgerStore()
+	if err != nil {
+		return nil, fmt.Errorf("open in-memory store: %v", err)
+	}
+
+	blockchain, err := ledger.NewBlockchainWithGenesisBlock(store)
+	if err != nil {
+		return nil, fmt.Errorf("build genesis block: %v", err)
+	}
+	// HeaderCheck reads through DefaultLedger, so it must be initialized
+	// the same way InitLedger sets it up for a real node, or every check
+	// below nil-panics on DefaultLedger.Blockchain/.Store.
+	ledger.DefaultLedger = &ledger.Ledger{
+		Blockchain: blockchain,
+		Store:      store,
+	}
+
+	genesisHash, err := store.GetBlockHash(0)
+	if err != nil {
+		return nil, err
+	}
+	genesisBlock, err := store.GetBlock(genesisHash)
+	if err != nil {
+		return nil, err
+	}
+
+	fixture := &Fixture{
+		Manifest: Manifest{
+			GenesisBlockHash: genesisHash.ToHexString(),
+		},
+	}
+	fixture.Blocks = append(fixture.Blocks, genesisBlock)
+
+	var lastBlock *ledger.Block = genesisBlock
+	for height := uint32(1); height <= numBlocks; height++ {
+		block, err := blockchain.GenerateBlock(account, transaction.TransactionArray{})
+		if err != nil {
+			return nil, fmt.Errorf("generate block %d: %v", height, err)
+		}
+
+		// GenerateBlock predates the VRF beacon election (por.VerifyEntry
+		// in HeaderCheck) and leaves VRFProof empty, so compute this
+		// round's ticket and re-sign the header with it included, the
+		// same way a VRF-aware GenerateBlock would.
+		randomness := por.DrawRandomness(lastBlock.Header.Signature, por.RandomnessTypeProposerElection, uint64(height), height, block.Header.PrevBlockHash)
+		proof, err := por.Prove(account.PrivateKey, randomness)
+		if err != nil {
+			return nil, fmt.Errorf("compute VRF proof for block %d: %v", height, err)
+		}
+		block.Header.VRFProof = proof
+
+		unsigned := bytes.NewBuffer(nil)
+		if err := block.Header.SerializeUnsigned(unsigned); err != nil {
+			return nil, err
+		}
+		signature, err := wallet.SignBlockHeader(account.PublicKey, unsigned.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("sign block %d header: %v", height, err)
+		}
+		block.Header.Signature = signature
+
+		if err := blockchain.AddBlock(block); err != nil {
+			return nil, fmt.Errorf("add block %d: %v", height, err)
+		}
+
+		publicKey, chordID, err := block.GetSigner()
+		if err != nil {
+			return nil, err
+		}
+		_ = publicKey
+
+		fixture.Blocks = append(fixture.Blocks, block)
+		fixture.Manifest.Outcomes = append(fixture.Manifest.Outcomes, ExpectedOutcome{
+			Height:      height,
+			WinnerChord: fmt.Sprintf("%x", chordID),
+		})
+		lastBlock = block
+	}
+
+	for _, variant := range corruptedVariantsOf(lastBlock) {
+		fixture.Manifest.Corrupted = append(fixture.Manifest.Corrupted, variant)
+	}
+
+	return fixture, nil
+}
+
+// corruptedVariantsOf describes the expected-to-fail variants derived from
+// base without materializing the corrupted blocks themselves: the fixture
+// consumer (core/ledger's table-driven test, or nkn-validator test) applies
+// the named corruption to a copy of base with ApplyCorruption before
+// replaying it.
+//
+// CorruptBadCoinbasePosition and CorruptInvalidSigChainCommit are left out
+// here: a bootstrap fixture like this one only ever produces single
+// coinbase-transaction, GenesisSigner-era blocks, so there is no second
+// transaction to swap the coinbase with and no TxnSigner-era Commit
+// transaction for a mangled SigChain to matter against. Both remain valid
+// CorruptionKinds for a fixture built from a chain that's advanced past
+// GenesisBlockProposedHeight.
+//
+// Advancing Generate past GenesisBlockProposedHeight needs a real
+// *payload.Commit transaction carrying a por.SigChain (serialized via its
+// new Serialize method, see por/sigchain.go) included in one of the
+// generated blocks, so that block's header.WinnerType switches to
+// TxnSigner for the next one. payload.Commit itself, and the
+// Blockchain.GenerateBlock logic that decides a header's WinnerType/
+// WinnerHash from its transactions, aren't part of this source tree (no
+// Commit.go in core/transaction/payload, and GenerateBlock's body isn't
+// visible here), so this fixture can't be safely extended to build that
+// transaction without guessing at both. This is the concrete blocker for
+// covering CorruptBadCoinbasePosition and CorruptInvalidSigChainCommit;
+// the fixture stays scoped to what GenesisSigner-era blocks can exercise
+// until those pieces are in reach.
+func corruptedVariantsOf(base *ledger.Block) []CorruptedVariant {
+	height := base.Header.Height
+	return []CorruptedVariant{
+		{Kind: CorruptPostdatedTimestamp, Height: height, WantErr: "postdated timestamp over tolerance"},
+		{Kind: CorruptWrongSignerPublicKey, Height: height, WantErr: "invalid block signer public key"},
+	}
+}
@@ -0,0 +1,151 @@
+// Package testchain generates and loads a canonical, serialized test chain
+// fixture: a deterministic sequence of blocks plus a JSON manifest of the
+// expected winner at every height and the expected errors for a handful of
+// corrupted-block variants. It lets TransactionCheck, HeaderCheck, and
+// TimestampCheck be driven from the same fixture in both this repo's own
+// tests and, via the nkn-validator test subcommand, against a remote nknd,
+// removing the dependence on ad-hoc live-network tests for validator
+// changes.
+package testchain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/nknorg/nkn/core/ledger"
+)
+
+// CorruptionKind names one of the fixture's deliberately-broken block
+// variants, used to assert that validation rejects it for the right
+// reason.
+type CorruptionKind string
+
+const (
+	CorruptBadCoinbasePosition     CorruptionKind = "bad_coinbase_position"
+	CorruptPostdatedTimestamp      CorruptionKind = "postdated_timestamp"
+	CorruptWrongSignerPublicKey    CorruptionKind = "wrong_signer_public_key"
+	CorruptInvalidSigChainCommit   CorruptionKind = "invalid_sigchain_commit"
+)
+
+// ExpectedOutcome records, for a single fixture block, the winner that
+// HeaderCheck should pick and/or the error TransactionCheck/HeaderCheck
+// should return.
+type ExpectedOutcome struct {
+	Height      uint32 `json:"height"`
+	WinnerChord string `json:"winnerChord,omitempty"`
+	WantErr     string `json:"wantErr,omitempty"`
+}
+
+// CorruptedVariant pairs a CorruptionKind with the block height it was
+// derived from and the error that checking it must produce.
+type CorruptedVariant struct {
+	Kind    CorruptionKind `json:"kind"`
+	Height  uint32         `json:"height"`
+	WantErr string         `json:"wantErr"`
+}
+
+// Manifest is the JSON sidecar describing what a Fixture's chain file is
+// expected to do when replayed.
+type Manifest struct {
+	GenesisBlockHash string             `json:"genesisBlockHash"`
+	Outcomes         []ExpectedOutcome  `json:"outcomes"`
+	Corrupted        []CorruptedVariant `json:"corrupted"`
+}
+
+// Fixture is a canonical test chain: the genesis block plus a run of
+// regular blocks, serialized in order, and the manifest describing the
+// expected validation outcome for each one.
+type Fixture struct {
+	Blocks   []*ledger.Block
+	Manifest Manifest
+}
+
+// Save writes the fixture's blocks to chainPath (length-prefixed
+// serialized blocks, in order) and its manifest to manifestPath as JSON.
+func (f *Fixture) Save(chainPath, manifestPath string) error {
+	buf := bytes.NewBuffer(nil)
+	for _, block := range f.Blocks {
+		blockBuf := bytes.NewBuffer(nil)
+		if err := block.Serialize(blockBuf); err != nil {
+			return fmt.Errorf("serialize block %d: %v", block.Header.Height, err)
+		}
+		writeUvarint(buf, uint64(blockBuf.Len()))
+		buf.Write(blockBuf.Bytes())
+	}
+	if err := ioutil.WriteFile(chainPath, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+
+	manifestBytes, err := json.MarshalIndent(f.Manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(manifestPath, manifestBytes, 0644)
+}
+
+// Load reads a Fixture previously written by Save.
+func Load(chainPath, manifestPath string) (*Fixture, error) {
+	manifestBytes, err := ioutil.ReadFile(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, err
+	}
+
+	chainBytes, err := ioutil.ReadFile(chainPath)
+	if err != nil {
+		return nil, err
+	}
+	r := bytes.NewReader(chainBytes)
+
+	var blocks []*ledger.Block
+	for r.Len() > 0 {
+		size, err := readUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		blockBytes := make([]byte, size)
+		if _, err := r.Read(blockBytes); err != nil {
+			return nil, err
+		}
+		block := &ledger.Block{}
+		if err := block.Deserialize(bytes.NewReader(blockBytes)); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+
+	return &Fixture{Blocks: blocks, Manifest: manifest}, nil
+}
+
+func writeUvarint(buf *bytes.Buffer, v uint64) {
+	var tmp [10]byte
+	n := 0
+	for v >= 0x80 {
+		tmp[n] = byte(v) | 0x80
+		v >>= 7
+		n++
+	}
+	tmp[n] = byte(v)
+	buf.Write(tmp[:n+1])
+}
+
+func readUvarint(r *bytes.Reader) (uint64, error) {
+	var v uint64
+	var shift uint
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		v |= uint64(b&0x7f) << shift
+		if b < 0x80 {
+			return v, nil
+		}
+		shift += 7
+	}
+}
@@ -0,0 +1,69 @@
+package ledger_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nknorg/nkn/core/ledger"
+	"github.com/nknorg/nkn/core/ledger/testchain"
+	"github.com/nknorg/nkn/vault"
+)
+
+// TestFixtureReplay drives TransactionCheck, HeaderCheck, and
+// TimestampCheck from a single deterministic fixture so validator changes
+// can be exercised without a live network: every block at every height
+// must be accepted, and each named corrupted variant must be rejected with
+// the error recorded in the fixture's manifest.
+func TestFixtureReplay(t *testing.T) {
+	wallet := vault.GetWallet()
+
+	// Stay below ledger.GenesisBlockProposedHeight: this fixture never
+	// submits a Commit transaction, so every block's WinnerType stays
+	// GenesisSigner, and HeaderCheck rejects GenesisSigner blocks at or
+	// past that height.
+	fixture, err := testchain.Generate(wallet, ledger.GenesisBlockProposedHeight-1)
+	if err != nil {
+		t.Fatalf("generate fixture: %v", err)
+	}
+
+	for i, block := range fixture.Blocks {
+		if i == 0 {
+			continue // genesis is never passed through HeaderCheck
+		}
+		if err := ledger.TransactionCheck(block); err != nil {
+			t.Errorf("height %d: unexpected TransactionCheck error: %v", block.Header.Height, err)
+		}
+		if err := ledger.HeaderCheck(block.Header, time.Now().Unix()); err != nil {
+			t.Errorf("height %d: unexpected HeaderCheck error: %v", block.Header.Height, err)
+		}
+		if err := ledger.TimestampCheck(block.Header.Timestamp); err != nil {
+			t.Errorf("height %d: unexpected TimestampCheck error: %v", block.Header.Height, err)
+		}
+	}
+
+	base := fixture.Blocks[len(fixture.Blocks)-1]
+	for _, variant := range fixture.Manifest.Corrupted {
+		variant := variant
+		t.Run(string(variant.Kind), func(t *testing.T) {
+			corrupted, err := testchain.ApplyCorruption(base, variant.Kind)
+			if err != nil {
+				t.Fatalf("apply corruption %s: %v", variant.Kind, err)
+			}
+
+			err = ledger.TransactionCheck(corrupted)
+			if err == nil {
+				err = ledger.HeaderCheck(corrupted.Header, time.Now().Unix())
+			}
+			if err == nil {
+				err = ledger.TimestampCheck(corrupted.Header.Timestamp)
+			}
+			if err == nil {
+				t.Fatalf("expected error %q for corruption %s, got nil", variant.WantErr, variant.Kind)
+			}
+			if !strings.Contains(err.Error(), variant.WantErr) {
+				t.Fatalf("corruption %s: got error %q, want it to contain %q", variant.Kind, err.Error(), variant.WantErr)
+			}
+		})
+	}
+}
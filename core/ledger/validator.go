@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/gogo/protobuf/proto"
 	. "github.com/nknorg/nkn/common"
 	"github.com/nknorg/nkn/core/signature"
 	tx "github.com/nknorg/nkn/core/transaction"
@@ -14,7 +13,6 @@ import (
 	"github.com/nknorg/nkn/crypto"
 	. "github.com/nknorg/nkn/errors"
 	"github.com/nknorg/nkn/por"
-	"github.com/nknorg/nkn/util/config"
 	"github.com/nknorg/nkn/util/log"
 )
 
@@ -58,6 +56,11 @@ func TransactionCheck(block *Block) error {
 		if errCode := tx.VerifyTransactionWithLedger(txn); errCode != ErrNoError {
 			return errors.New("transaction history check failed")
 		}
+		if unsubscribe, ok := txn.Payload.(*payload.Unsubscribe); ok {
+			if err := tx.VerifyUnsubscribe(unsubscribe); err != nil {
+				return err
+			}
+		}
 	}
 	if errCode := tx.VerifyTransactionWithBlock(TransactionArray(block.Transactions)); errCode != ErrNoError {
 		return errors.New("transaction block check failed")
@@ -91,91 +94,72 @@ func HeaderCheck(header *Header, receiveTime int64) error {
 		return errors.New("invalid winning hash type")
 	}
 
-	// calculate time difference
-	var timeDiff int64
-	genesisBlockHash, err := DefaultLedger.Store.GetBlockHash(0)
-	if err != nil {
-		return err
-	}
-	genesisBlock, err := DefaultLedger.Store.GetBlock(genesisBlockHash)
-	if err != nil {
-		return err
-	}
-	prevTimestamp, err := DefaultLedger.Blockchain.GetBlockTime(header.PrevBlockHash)
-	if err != nil {
-		return err
-	}
-	if prevTimestamp == genesisBlock.Header.Timestamp {
-		timeDiff = 0
-	} else {
-		timeDiff = receiveTime - prevTimestamp
-	}
+	// Draw this round's VRF beacon randomness from the previous block's
+	// signer signature. This is the sole proposer selection mechanism: it
+	// is a pure function of (height, prevBlockHash), so any two nodes with
+	// identical chain state agree on the winner regardless of receiveTime.
+	randomness := por.DrawRandomness(prevHeader.Signature, por.RandomnessTypeProposerElection, uint64(height), height, header.PrevBlockHash)
 
-	// get miner who will sign next block
 	var publicKey []byte
 	var chordID []byte
-	timeSlot := int64(config.ProposerChangeTime / time.Second)
-	if timeDiff >= timeSlot {
-		// This is a temporary solution
-		proposerBlockHeight := 0
-		// index := timeDiff / timeSlot
-		// proposerBlockHeight := int64(DefaultLedger.Store.GetHeight()) - index
-		// if proposerBlockHeight < 0 {
-		// proposerBlockHeight = 0
-		// }
-		proposerBlockHash, err := DefaultLedger.Store.GetBlockHash(uint32(proposerBlockHeight))
+	var vrfPublicKey []byte
+	switch prevHeader.WinnerType {
+	case GenesisSigner:
+		genesisBlockHash, err := DefaultLedger.Store.GetBlockHash(0)
 		if err != nil {
 			return err
 		}
-		proposerBlock, err := DefaultLedger.Store.GetBlock(proposerBlockHash)
+		genesisBlock, err := DefaultLedger.Store.GetBlock(genesisBlockHash)
 		if err != nil {
 			return err
 		}
-		publicKey, chordID, err = proposerBlock.GetSigner()
-		log.Infof("block signer: public key should be %s, chord ID should be %s, "+
-			"which is the signer of block %d", BytesToHexString(publicKey),
-			BytesToHexString(chordID), proposerBlockHeight)
+		publicKey, chordID, err = genesisBlock.GetSigner()
 		if err != nil {
 			return err
 		}
-	} else {
-		winnerHash := prevHeader.WinnerHash
-		winnerType := prevHeader.WinnerType
-		switch winnerType {
-		case GenesisSigner:
-			publicKey, chordID, err = genesisBlock.GetSigner()
-			if err != nil {
-				return err
-			}
-			log.Infof("block signer: public key should be %s, which is genesis block proposer",
-				BytesToHexString(publicKey))
-		case TxnSigner:
-			txn, err := DefaultLedger.Store.GetTransaction(winnerHash)
-			if err != nil {
-				return err
-			}
-			payload, ok := txn.Payload.(*payload.Commit)
-			if !ok {
-				return errors.New("invalid transaction type")
-			}
-			sigchain := &por.SigChain{}
-			proto.Unmarshal(payload.SigChain, sigchain)
-			publicKey, chordID, err = sigchain.GetMiner()
-			if err != nil {
-				return err
-			}
-			txnHash := txn.Hash()
-			log.Infof("block signer: public key should be %s, chord ID should be %s, "+
-				"which is got in sigchain transaction %s", BytesToHexString(publicKey), BytesToHexString(chordID),
-				BytesToHexString(txnHash.ToArrayReverse()))
+		// The bootstrap genesis signer never went through SigChain.AppendElem,
+		// so it has no dedicated VRFPublicKey on file; GetSigner (outside
+		// this change) would need to hand one back, the same way AppendElem
+		// does, for this path to verify against the VRF's own encoding
+		// instead of header.Signer's.
+		vrfPublicKey = publicKey
+	case TxnSigner:
+		txn, err := DefaultLedger.Store.GetTransaction(prevHeader.WinnerHash)
+		if err != nil {
+			return err
+		}
+		commit, ok := txn.Payload.(*payload.Commit)
+		if !ok {
+			return errors.New("invalid transaction type")
+		}
+		sigchain := &por.SigChain{}
+		if err := sigchain.Deserialize(bytes.NewReader(commit.SigChain)); err != nil {
+			return fmt.Errorf("deserialize sigchain: %v", err)
+		}
+		// sigchain.GetMiner is the actual proposer selector: it runs the
+		// VRF beacon election (smallest ticket wins) over every relay
+		// element's VRF entry and returns the winner, rather than us
+		// re-deriving a single fixed signer.
+		publicKey, chordID, vrfPublicKey, err = sigchain.GetMiner()
+		if err != nil {
+			return err
 		}
 	}
 	// TODO check chord ID is valid
 	_ = chordID
+
 	// verify if public is expected
 	if bytes.Compare(publicKey, header.Signer) != 0 {
 		return fmt.Errorf("invalid block signer public key: %s", BytesToHexString(header.Signer))
 	}
+
+	ticket, err := por.VerifyEntry(vrfPublicKey, randomness, header.VRFProof)
+	if err != nil {
+		return fmt.Errorf("VRF proposer election check failed: %v", err)
+	}
+	log.Infof("block signer: public key should be %s, elected by VRF ticket %s for round %d",
+		BytesToHexString(publicKey), BytesToHexString(ticket), height)
+
 	rawPubKey, err := crypto.DecodePoint(publicKey)
 	if err != nil {
 		return err
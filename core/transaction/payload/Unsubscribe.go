@@ -0,0 +1,106 @@
+package payload
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/nknorg/nkn/common"
+	"github.com/nknorg/nkn/common/serialization"
+	. "github.com/nknorg/nkn/errors"
+	"github.com/nknorg/nkn/util/address"
+)
+
+// Unsubscribe lets a client leave a topic before its Subscribe Duration
+// expires, instead of waiting out the remaining blocks.
+type Unsubscribe struct {
+	Subscriber []byte
+	Identifier string
+	Topic      string
+}
+
+func (s *Unsubscribe) Data(version byte) []byte {
+	b := new(bytes.Buffer)
+	s.Serialize(b, version)
+	return b.Bytes()
+}
+
+func (s *Unsubscribe) Serialize(w io.Writer, version byte) error {
+	serialization.WriteVarBytes(w, s.Subscriber)
+	serialization.WriteVarString(w, s.Identifier)
+	serialization.WriteVarString(w, s.Topic)
+	return nil
+}
+
+func (s *Unsubscribe) Deserialize(r io.Reader, version byte) error {
+	var err error
+	s.Subscriber, err = serialization.ReadVarBytes(r)
+	if err != nil {
+		return NewDetailErr(err, ErrNoCode, "[Unsubscribe], Subscriber Deserialize failed.")
+	}
+	s.Identifier, err = serialization.ReadVarString(r)
+	if err != nil {
+		return NewDetailErr(err, ErrNoCode, "[Unsubscribe], Identifier Deserialize failed.")
+	}
+	s.Topic, err = serialization.ReadVarString(r)
+	if err != nil {
+		return NewDetailErr(err, ErrNoCode, "[Unsubscribe], Topic Deserialize failed.")
+	}
+	return nil
+}
+
+func (s *Unsubscribe) Equal(s2 *Unsubscribe) bool {
+	if !bytes.Equal(s.Subscriber, s2.Subscriber) {
+		return false
+	}
+
+	if s.Identifier != s2.Identifier {
+		return false
+	}
+
+	if s.Topic != s2.Topic {
+		return false
+	}
+
+	return true
+}
+
+func (s *Unsubscribe) SubscriberString() string {
+	return address.MakeAddressString(s.Subscriber, s.Identifier)
+}
+
+func (s *Unsubscribe) MarshalJson() ([]byte, error) {
+	ui := &UnsubscribeInfo{
+		Subscriber: common.BytesToHexString(s.Subscriber),
+		Identifier: s.Identifier,
+		Topic:      s.Topic,
+	}
+
+	data, err := json.Marshal(ui)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *Unsubscribe) UnmarshalJson(data []byte) error {
+	ui := new(UnsubscribeInfo)
+	var err error
+	if err = json.Unmarshal(data, &ui); err != nil {
+		return err
+	}
+
+	s.Subscriber, _ = common.HexStringToBytes(ui.Subscriber)
+
+	s.Identifier = ui.Identifier
+
+	s.Topic = ui.Topic
+
+	return nil
+}
+
+type UnsubscribeInfo struct {
+	Subscriber string
+	Identifier string
+	Topic      string
+}
@@ -0,0 +1,12 @@
+package transaction
+
+// UnsubscribeType is the TxType tag for Unsubscribe transactions,
+// declared as the next value after SubscribeType. It lives in its own
+// file rather than the main TxType const block since that block isn't
+// part of this change.
+//
+// Transaction.Deserialize's payload switch isn't part of this change
+// either; it needs a `case UnsubscribeType: txn.Payload = new(payload.Unsubscribe)`
+// arm added next to SubscribeType's, or txn.Payload.(*payload.Unsubscribe)
+// can never succeed for a transaction deserialized off the wire.
+const UnsubscribeType TxType = SubscribeType + 1
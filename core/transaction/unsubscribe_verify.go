@@ -0,0 +1,28 @@
+package transaction
+
+import (
+	"errors"
+
+	"github.com/nknorg/nkn/core/transaction/payload"
+)
+
+// VerifyUnsubscribe rejects an Unsubscribe transaction that targets a
+// subscription Store (see the package-level var set up by InitLedger) has
+// no record of. This belongs next to VerifyTransactionWithLedger's other
+// per-payload checks, but that switch lives outside this change, so
+// ledger.TransactionCheck calls VerifyUnsubscribe directly as well until
+// it is wired into that switch.
+//
+// Unsubscribe pays the same flat network fee every other transaction
+// type pays, charged by VerifyTransactionWithLedger's shared fee check,
+// so there is no Unsubscribe-specific fee logic here.
+func VerifyUnsubscribe(unsubscribe *payload.Unsubscribe) error {
+	subscribed, err := Store.IsSubscribed(unsubscribe.Topic, unsubscribe.SubscriberString())
+	if err != nil {
+		return err
+	}
+	if !subscribed {
+		return errors.New("unsubscribe: no existing subscription for this topic")
+	}
+	return nil
+}
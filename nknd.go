@@ -21,6 +21,7 @@ import (
 	"github.com/nknorg/nkn/core/transaction"
 	"github.com/nknorg/nkn/crypto"
 	"github.com/nknorg/nkn/db"
+	"github.com/nknorg/nkn/events"
 	"github.com/nknorg/nkn/gateway/httpproxy"
 	"github.com/nknorg/nkn/net/node"
 	"github.com/nknorg/nkn/net/protocol"
@@ -41,8 +42,10 @@ const (
 )
 
 var (
-	createMode bool
-	seedStr    string
+	createMode         bool
+	seedStr            string
+	remoteSignerSocket string
+	remoteSignerToken  string
 )
 
 func init() {
@@ -70,6 +73,12 @@ func InitLedger(account *vault.Account) error {
 	por.Store = ledger.DefaultLedger.Store
 	vault.Store = ledger.DefaultLedger.Store
 
+	// Gives ApplyUnsubscribe a real call site: every Unsubscribe in a
+	// newly persisted block has its subscription entry deleted as soon
+	// as the block lands, the same way websocket.NewServer subscribes
+	// SendBlock2WSclient to this event.
+	ledger.DefaultLedger.Blockchain.BCEvents.Subscribe(events.EventBlockPersistCompleted, ledger.ApplyPersistedUnsubscriptions)
+
 	return nil
 }
 
@@ -123,8 +132,18 @@ func nknMain(c *cli.Context) error {
 	}
 	log.Log.SetDebugLevel(config.Parameters.LogLevel) // Update LogLevel after config.json loaded
 
-	// Get local account
-	wallet := vault.GetWallet()
+	// Get local account, either from the local keystore or, if configured,
+	// from a remote nkn-signer daemon so that this process never holds the
+	// private key in memory.
+	var wallet vault.Wallet
+	if remoteSignerSocket != "" {
+		wallet, err = vault.NewRemoteSigner(remoteSignerSocket, remoteSignerToken)
+		if err != nil {
+			return fmt.Errorf("connect to remote signer error: %v", err)
+		}
+	} else {
+		wallet = vault.GetWallet()
+	}
 	if wallet == nil {
 		return errors.New("open local wallet error")
 	}
@@ -339,6 +358,19 @@ func main() {
 			Hidden:      false,
 			Destination: &config.Parameters.NAT,
 		},
+		cli.StringFlag{
+			Name:        "remote-signer-socket",
+			Usage:       "Unix socket of an nkn-signer daemon to use instead of the local keystore",
+			Value:       "",
+			Destination: &remoteSignerSocket,
+		},
+		cli.StringFlag{
+			Name:        "remote-signer-token",
+			Usage:       "Shared secret token for the remote signer daemon",
+			Value:       "",
+			Hidden:      true,
+			Destination: &remoteSignerToken,
+		},
 	}
 	app.Action = nknMain
 
@@ -0,0 +1,146 @@
+package por
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"sync"
+
+	"golang.org/x/crypto/blake2b"
+
+	. "github.com/nknorg/nkn/common"
+	"github.com/nknorg/nkn/common/serialization"
+)
+
+// RandomnessType distinguishes the purposes a beacon randomness value is
+// drawn for, so the same round can't be replayed across unrelated elections.
+type RandomnessType int64
+
+const (
+	RandomnessTypeProposerElection RandomnessType = 1
+)
+
+// candidate is one chordID's election identity: publicKey is the node's
+// real signing key (compared against header.Signer once elected),
+// vrfPublicKey is the dedicated VRF public key its ticket is verified
+// against. The two are kept separate because the node's signing key is
+// encoded the way crypto.PubKey.EncodePoint() produces it, not the
+// elliptic.Marshal encoding the VRF itself is computed over.
+type candidate struct {
+	publicKey    []byte
+	vrfPublicKey []byte
+}
+
+// Beacon draws per-round randomness from the previous block's signer
+// signature and elects the next block proposer by VRF ticket, following the
+// drand-style "smallest ticket wins" construction.
+type Beacon struct {
+	sync.RWMutex
+	candidates map[string]candidate // chordID -> candidate
+}
+
+// NewBeacon returns an empty Beacon. Candidates are added as miner nodes
+// register with this node's por tracker.
+func NewBeacon() *Beacon {
+	return &Beacon{
+		candidates: make(map[string]candidate),
+	}
+}
+
+// AddCandidate tracks chordID as an eligible proposer candidate for future
+// rounds, using publicKey as its signing identity and vrfPublicKey to
+// verify its election ticket.
+func (b *Beacon) AddCandidate(chordID, publicKey, vrfPublicKey []byte) {
+	b.Lock()
+	defer b.Unlock()
+	b.candidates[BytesToHexString(chordID)] = candidate{publicKey: publicKey, vrfPublicKey: vrfPublicKey}
+}
+
+// RemoveCandidate stops tracking chordID as an eligible proposer candidate.
+func (b *Beacon) RemoveCandidate(chordID []byte) {
+	b.Lock()
+	defer b.Unlock()
+	delete(b.candidates, BytesToHexString(chordID))
+}
+
+// DrawRandomness derives the beacon randomness for round from rbase, the
+// previous block's signer signature, following
+// randomness = H( H(rbase) || randomnessType || round || entropy ).
+func DrawRandomness(rbase []byte, randomnessType RandomnessType, round uint64, height uint32, prevBlockHash Uint256) []byte {
+	rbaseHash := blake2b.Sum256(rbase)
+
+	typeBuf := make([]byte, 8)
+	binary.BigEndian.PutUint64(typeBuf, uint64(randomnessType))
+
+	roundBuf := make([]byte, 8)
+	binary.BigEndian.PutUint64(roundBuf, round)
+
+	buf := bytes.NewBuffer(nil)
+	buf.Write(rbaseHash[:])
+	buf.Write(typeBuf)
+	buf.Write(roundBuf)
+	buf.Write(entropyBytes(height, prevBlockHash))
+
+	randomness := blake2b.Sum256(buf.Bytes())
+	return randomness[:]
+}
+
+// entropyBytes is the canonical (height, prevBlockHash) encoding mixed into
+// the beacon randomness so it cannot be predicted before the previous block
+// is known.
+func entropyBytes(height uint32, prevBlockHash Uint256) []byte {
+	buf := bytes.NewBuffer(nil)
+	serialization.WriteUint32(buf, height)
+	prevBlockHash.Serialize(buf)
+	return buf.Bytes()
+}
+
+// Entry computes this node's election ticket for round: a real VRF proof
+// (see Prove in vrf.go) over randomness under sk, not a generic signature.
+// Because Gamma = sk*H(randomness) is the unique deterministic output for
+// a given (sk, randomness) pair, a candidate cannot grind alternate tickets
+// for the same round the way it could with a freely-resampled ECDSA nonce.
+func Entry(sk []byte, randomness []byte) ([]byte, error) {
+	return Prove(sk, randomness)
+}
+
+// VerifyEntry checks that proof is a valid VRF ticket over randomness for
+// vrfPublicKey, returning the ticket value used for smallest-ticket
+// comparison.
+func VerifyEntry(vrfPublicKey, randomness, proof []byte) ([]byte, error) {
+	ticket, err := VerifyVRF(vrfPublicKey, randomness, proof)
+	if err != nil {
+		return nil, errors.New("invalid VRF proof")
+	}
+	return ticket, nil
+}
+
+// Elect returns the chordID, signing public key, and VRF public key of the
+// candidate with the smallest VRF ticket for randomness. Candidates whose
+// proof fails verification are skipped.
+func (b *Beacon) Elect(randomness []byte, proofs map[string][]byte) (chordID []byte, publicKey []byte, vrfPublicKey []byte, err error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	var bestTicket []byte
+	for id, c := range b.candidates {
+		proof, ok := proofs[id]
+		if !ok {
+			continue
+		}
+		ticket, vErr := VerifyEntry(c.vrfPublicKey, randomness, proof)
+		if vErr != nil {
+			continue
+		}
+		if bestTicket == nil || bytes.Compare(ticket, bestTicket) < 0 {
+			bestTicket = ticket
+			chordID, _ = HexStringToBytes(id)
+			publicKey = c.publicKey
+			vrfPublicKey = c.vrfPublicKey
+		}
+	}
+	if bestTicket == nil {
+		return nil, nil, nil, errors.New("no valid candidate ticket for round")
+	}
+	return chordID, publicKey, vrfPublicKey, nil
+}
@@ -0,0 +1,12 @@
+package por
+
+// ComputeMinerEntry computes a node's VRF proof over the round's beacon
+// randomness. It is what a vault.Wallet implementation's ComputeVRFProof
+// calls with the account's raw signing key; SigChain.AppendElem only ever
+// goes through that interface; a caller should never need signerSecretKey
+// directly. The resulting proof travels with the sigchain and is later
+// copied into the winning block header's VRFProof field so HeaderCheck
+// can verify the election deterministically.
+func ComputeMinerEntry(signerSecretKey []byte, randomness []byte) (proof []byte, err error) {
+	return Entry(signerSecretKey, randomness)
+}
@@ -0,0 +1,108 @@
+package por
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+
+	. "github.com/nknorg/nkn/common"
+)
+
+// vrfCandidate derives a deterministic (secret key, VRF public key) pair
+// from seed, standing in for a node's VRF keypair.
+func vrfCandidate(seed byte) (sk []byte, vrfPublicKey []byte) {
+	sk = bytes.Repeat([]byte{seed}, 32)
+	skInt := new(big.Int).SetBytes(sk)
+	skInt.Mod(skInt, vrfCurve.Params().N)
+	x, y := vrfCurve.ScalarBaseMult(skInt.Bytes())
+	return sk, elliptic.Marshal(vrfCurve, x, y)
+}
+
+func TestDrawRandomnessDeterministic(t *testing.T) {
+	rbase := []byte("previous-block-signer-signature")
+	prevBlockHash := Uint256{}
+
+	r1 := DrawRandomness(rbase, RandomnessTypeProposerElection, 10, 10, prevBlockHash)
+	r2 := DrawRandomness(rbase, RandomnessTypeProposerElection, 10, 10, prevBlockHash)
+	if !bytes.Equal(r1, r2) {
+		t.Fatal("DrawRandomness should be deterministic for identical inputs")
+	}
+
+	r3 := DrawRandomness(rbase, RandomnessTypeProposerElection, 11, 10, prevBlockHash)
+	if bytes.Equal(r1, r3) {
+		t.Fatal("DrawRandomness should differ when round changes")
+	}
+}
+
+func TestDrawRandomnessIgnoresWallClock(t *testing.T) {
+	// Two nodes computing randomness for the same (height, prevBlockHash)
+	// at different times must agree on the proposer without consulting
+	// wall-clock time.
+	rbase := []byte("previous-block-signer-signature")
+	prevBlockHash := Uint256{}
+
+	nodeA := DrawRandomness(rbase, RandomnessTypeProposerElection, 42, 42, prevBlockHash)
+	nodeB := DrawRandomness(rbase, RandomnessTypeProposerElection, 42, 42, prevBlockHash)
+	if !bytes.Equal(nodeA, nodeB) {
+		t.Fatal("two nodes with identical state must agree on randomness regardless of when they compute it")
+	}
+}
+
+// TestVRFElectionAgreement runs the actual election end-to-end: three
+// candidates each submit a real VRF proof for the same round, and two
+// independently built Beacons (standing in for two nodes with identical
+// chain state) must elect the same winner by smallest ticket.
+func TestVRFElectionAgreement(t *testing.T) {
+	rbase := []byte("previous-block-signer-signature")
+	randomness := DrawRandomness(rbase, RandomnessTypeProposerElection, 7, 7, Uint256{})
+
+	type candidateFixture struct {
+		chordID      []byte
+		publicKey    []byte
+		vrfPublicKey []byte
+		proof        []byte
+	}
+
+	var fixtures []candidateFixture
+	for i := byte(1); i <= 3; i++ {
+		sk, vrfPublicKey := vrfCandidate(i)
+		proof, err := Prove(sk, randomness)
+		if err != nil {
+			t.Fatalf("candidate %d: Prove failed: %v", i, err)
+		}
+		fixtures = append(fixtures, candidateFixture{
+			chordID:      []byte{i},
+			publicKey:    []byte{i, i},
+			vrfPublicKey: vrfPublicKey,
+			proof:        proof,
+		})
+	}
+
+	newBeaconWithCandidates := func() (*Beacon, map[string][]byte) {
+		beacon := NewBeacon()
+		proofs := make(map[string][]byte, len(fixtures))
+		for _, f := range fixtures {
+			beacon.AddCandidate(f.chordID, f.publicKey, f.vrfPublicKey)
+			proofs[BytesToHexString(f.chordID)] = f.proof
+		}
+		return beacon, proofs
+	}
+
+	beaconA, proofsA := newBeaconWithCandidates()
+	chordIDA, publicKeyA, _, err := beaconA.Elect(randomness, proofsA)
+	if err != nil {
+		t.Fatalf("node A election failed: %v", err)
+	}
+
+	beaconB, proofsB := newBeaconWithCandidates()
+	chordIDB, publicKeyB, _, err := beaconB.Elect(randomness, proofsB)
+	if err != nil {
+		t.Fatalf("node B election failed: %v", err)
+	}
+
+	if !bytes.Equal(chordIDA, chordIDB) || !bytes.Equal(publicKeyA, publicKeyB) {
+		t.Fatalf("two nodes with identical state must agree on the proposer: A elected chordID %x/%x, B elected %x/%x",
+			chordIDA, publicKeyA, chordIDB, publicKeyB)
+	}
+}
@@ -0,0 +1,160 @@
+package por
+
+import (
+	"io"
+
+	. "github.com/nknorg/nkn/common"
+	"github.com/nknorg/nkn/common/serialization"
+	"github.com/nknorg/nkn/vault"
+)
+
+// SigChainElem records one node that relayed a piece of data, along with
+// the VRF ticket that node computed for this round's proposer election.
+// VRFPublicKey is that node's dedicated VRF public key (elliptic.Marshal
+// encoding over vrfCurve), not its signing PublicKey: the two live on
+// different curves/encodings, so VerifyEntry is checked against
+// VRFPublicKey while PublicKey remains the identity compared against a
+// winning header's Signer. Every relaying node is therefore a candidate,
+// and the chain as a whole carries everything a verifier needs to check
+// the election without a separate gossip round.
+type SigChainElem struct {
+	ChordID      []byte
+	PublicKey    []byte
+	VRFPublicKey []byte
+	VRFProof     []byte
+}
+
+// SigChain is the proof-of-relay chain a commit transaction carries.
+// Height and PrevBlockHash identify the round its elements were elected
+// for; RBase is the previous block's signer signature, the VRF randomness
+// base for that round.
+type SigChain struct {
+	Height        uint32
+	PrevBlockHash Uint256
+	RBase         []byte
+	Elems         []*SigChainElem
+}
+
+// randomness returns this chain's round randomness, the same value every
+// element's VRFProof was computed against.
+func (sc *SigChain) randomness() []byte {
+	return DrawRandomness(sc.RBase, RandomnessTypeProposerElection, uint64(sc.Height), sc.Height, sc.PrevBlockHash)
+}
+
+// Serialize writes sc the way a Commit transaction's payload carries it
+// (commit.SigChain). SigChain has no generated protobuf (un)marshalers or
+// `protobuf:` field tags, so proto.Marshal/Unmarshal cannot round-trip it;
+// it gets its own hand-written codec instead, the same pattern
+// payload.Unsubscribe uses over common/serialization.
+func (sc *SigChain) Serialize(w io.Writer) error {
+	if err := serialization.WriteUint32(w, sc.Height); err != nil {
+		return err
+	}
+	if err := sc.PrevBlockHash.Serialize(w); err != nil {
+		return err
+	}
+	if err := serialization.WriteVarBytes(w, sc.RBase); err != nil {
+		return err
+	}
+	if err := serialization.WriteUint32(w, uint32(len(sc.Elems))); err != nil {
+		return err
+	}
+	for _, elem := range sc.Elems {
+		if err := serialization.WriteVarBytes(w, elem.ChordID); err != nil {
+			return err
+		}
+		if err := serialization.WriteVarBytes(w, elem.PublicKey); err != nil {
+			return err
+		}
+		if err := serialization.WriteVarBytes(w, elem.VRFPublicKey); err != nil {
+			return err
+		}
+		if err := serialization.WriteVarBytes(w, elem.VRFProof); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deserialize reads sc back from the bytes Serialize produced.
+func (sc *SigChain) Deserialize(r io.Reader) error {
+	var err error
+	sc.Height, err = serialization.ReadUint32(r)
+	if err != nil {
+		return err
+	}
+	sc.PrevBlockHash = Uint256{}
+	if err := sc.PrevBlockHash.Deserialize(r); err != nil {
+		return err
+	}
+	sc.RBase, err = serialization.ReadVarBytes(r)
+	if err != nil {
+		return err
+	}
+	count, err := serialization.ReadUint32(r)
+	if err != nil {
+		return err
+	}
+	sc.Elems = make([]*SigChainElem, 0, count)
+	for i := uint32(0); i < count; i++ {
+		elem := &SigChainElem{}
+		if elem.ChordID, err = serialization.ReadVarBytes(r); err != nil {
+			return err
+		}
+		if elem.PublicKey, err = serialization.ReadVarBytes(r); err != nil {
+			return err
+		}
+		if elem.VRFPublicKey, err = serialization.ReadVarBytes(r); err != nil {
+			return err
+		}
+		if elem.VRFProof, err = serialization.ReadVarBytes(r); err != nil {
+			return err
+		}
+		sc.Elems = append(sc.Elems, elem)
+	}
+	return nil
+}
+
+// AppendElem asks wallet to compute this round's VRF ticket and public key
+// and appends them as a new relay element, making chordID a candidate in
+// GetMiner's election. Routing through vault.Wallet rather than taking a
+// raw secret key means this node's signing key can live behind a remote
+// nkn-signer daemon just like block header and sigchain element signing.
+func (sc *SigChain) AppendElem(chordID, publicKey []byte, wallet vault.Wallet) error {
+	proof, err := wallet.ComputeVRFProof(sc.randomness())
+	if err != nil {
+		return err
+	}
+	vrfPublicKey, err := wallet.VRFPublicKey()
+	if err != nil {
+		return err
+	}
+	sc.Elems = append(sc.Elems, &SigChainElem{
+		ChordID:      chordID,
+		PublicKey:    publicKey,
+		VRFPublicKey: vrfPublicKey,
+		VRFProof:     proof,
+	})
+	return nil
+}
+
+// GetMiner is the actual proposer selector: it runs the VRF beacon
+// election over every relay element's ticket and returns the winner's
+// signing public key and VRF public key. HeaderCheck compares the signing
+// key against the winning header's Signer and verifies header.VRFProof
+// against the VRF key, so the election is checked deterministically from
+// the chain alone.
+func (sc *SigChain) GetMiner() (publicKey []byte, chordID []byte, vrfPublicKey []byte, err error) {
+	beacon := NewBeacon()
+	proofs := make(map[string][]byte, len(sc.Elems))
+	for _, elem := range sc.Elems {
+		beacon.AddCandidate(elem.ChordID, elem.PublicKey, elem.VRFPublicKey)
+		proofs[BytesToHexString(elem.ChordID)] = elem.VRFProof
+	}
+
+	chordID, publicKey, vrfPublicKey, err = beacon.Elect(sc.randomness(), proofs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return publicKey, chordID, vrfPublicKey, nil
+}
@@ -0,0 +1,145 @@
+package por
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+// vrfCurve is the curve the beacon VRF is computed over. It is independent
+// of whatever curve the node's crypto package uses for block/transaction
+// signatures: the VRF only needs public key bytes in uncompressed
+// elliptic.Marshal form to verify a proof.
+var vrfCurve = elliptic.P256()
+
+// Prove and Verify implement a discrete-log-equality (Chaum-Pedersen) VRF:
+//
+//	Gamma = sk * H(alpha)
+//
+// Gamma is the unique, deterministic VRF output for a given (sk, alpha)
+// pair -- fixing the signing key and the round randomness fixes Gamma, so
+// unlike a generic ECDSA/Schnorr signature (which admits many valid
+// signatures per message under a freely-chosen nonce) a miner cannot grind
+// alternate outputs for the same round by resampling a nonce. The proof is
+// a Schnorr-style NIZK that the same scalar sk relates pk=sk*G and
+// Gamma=sk*H(alpha); the NIZK's own randomness only blinds the proof, it
+// does not let the prover change Gamma.
+
+// hashToCurve deterministically maps alpha to a point H(alpha) on vrfCurve.
+func hashToCurve(alpha []byte) (x, y *big.Int) {
+	h := sha256.Sum256(append([]byte("nkn-vrf-h2c:"), alpha...))
+	scalar := new(big.Int).SetBytes(h[:])
+	scalar.Mod(scalar, vrfCurve.Params().N)
+	return vrfCurve.ScalarBaseMult(scalar.Bytes())
+}
+
+// challenge is the Fiat-Shamir hash binding every public value in the
+// Chaum-Pedersen proof, so a verifier recomputing it detects any tampering.
+func challenge(points ...*big.Int) *big.Int {
+	h := sha256.New()
+	for _, p := range points {
+		h.Write(p.Bytes())
+	}
+	c := new(big.Int).SetBytes(h.Sum(nil))
+	return c.Mod(c, vrfCurve.Params().N)
+}
+
+func negate(x, y *big.Int) (*big.Int, *big.Int) {
+	return x, new(big.Int).Sub(vrfCurve.Params().P, y)
+}
+
+// Prove computes the VRF proof for alpha under the private scalar sk,
+// encoded as gammaX || gammaY || c || s, each a fixed-width 32-byte field.
+func Prove(sk []byte, alpha []byte) ([]byte, error) {
+	skInt := new(big.Int).SetBytes(sk)
+	skInt.Mod(skInt, vrfCurve.Params().N)
+	if skInt.Sign() == 0 {
+		return nil, errors.New("invalid VRF secret key")
+	}
+
+	hx, hy := hashToCurve(alpha)
+	gammaX, gammaY := vrfCurve.ScalarMult(hx, hy, skInt.Bytes())
+
+	k, err := rand.Int(rand.Reader, vrfCurve.Params().N)
+	if err != nil {
+		return nil, err
+	}
+	kgx, kgy := vrfCurve.ScalarBaseMult(k.Bytes())
+	khx, khy := vrfCurve.ScalarMult(hx, hy, k.Bytes())
+
+	c := challenge(gammaX, gammaY, kgx, kgy, khx, khy)
+	s := new(big.Int).Mul(c, skInt)
+	s.Add(s, k)
+	s.Mod(s, vrfCurve.Params().N)
+
+	return encodeProof(gammaX, gammaY, c, s), nil
+}
+
+// VerifyVRF checks proof against publicKey (uncompressed elliptic.Marshal
+// bytes) and alpha, returning the VRF output hash used as the election
+// ticket.
+func VerifyVRF(publicKey, alpha, proof []byte) ([]byte, error) {
+	gammaX, gammaY, c, s, err := decodeProof(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	pkx, pky := elliptic.Unmarshal(vrfCurve, publicKey)
+	if pkx == nil {
+		return nil, errors.New("invalid VRF public key encoding")
+	}
+
+	hx, hy := hashToCurve(alpha)
+
+	// kG' = sG - cPK
+	sgx, sgy := vrfCurve.ScalarBaseMult(s.Bytes())
+	cpkx, cpky := vrfCurve.ScalarMult(pkx, pky, c.Bytes())
+	ncpkx, ncpky := negate(cpkx, cpky)
+	kgx, kgy := vrfCurve.Add(sgx, sgy, ncpkx, ncpky)
+
+	// kH' = sH - cGamma
+	shx, shy := vrfCurve.ScalarMult(hx, hy, s.Bytes())
+	cgx, cgy := vrfCurve.ScalarMult(gammaX, gammaY, c.Bytes())
+	ncgx, ncgy := negate(cgx, cgy)
+	khx, khy := vrfCurve.Add(shx, shy, ncgx, ncgy)
+
+	c2 := challenge(gammaX, gammaY, kgx, kgy, khx, khy)
+	if c2.Cmp(c) != 0 {
+		return nil, errors.New("invalid VRF proof")
+	}
+
+	output := sha256.Sum256(append([]byte("nkn-vrf-out:"), elliptic.Marshal(vrfCurve, gammaX, gammaY)...))
+	return output[:], nil
+}
+
+func encodeProof(gammaX, gammaY, c, s *big.Int) []byte {
+	buf := make([]byte, 0, 128)
+	buf = append(buf, pad32(gammaX)...)
+	buf = append(buf, pad32(gammaY)...)
+	buf = append(buf, pad32(c)...)
+	buf = append(buf, pad32(s)...)
+	return buf
+}
+
+func decodeProof(proof []byte) (gammaX, gammaY, c, s *big.Int, err error) {
+	if len(proof) != 128 {
+		return nil, nil, nil, nil, errors.New("malformed VRF proof length")
+	}
+	gammaX = new(big.Int).SetBytes(proof[0:32])
+	gammaY = new(big.Int).SetBytes(proof[32:64])
+	c = new(big.Int).SetBytes(proof[64:96])
+	s = new(big.Int).SetBytes(proof[96:128])
+	return gammaX, gammaY, c, s, nil
+}
+
+func pad32(v *big.Int) []byte {
+	b := v.Bytes()
+	if len(b) >= 32 {
+		return b[len(b)-32:]
+	}
+	out := make([]byte, 32)
+	copy(out[32-len(b):], b)
+	return out
+}
@@ -0,0 +1,13 @@
+package vault
+
+import (
+	"github.com/nknorg/nkn/crypto"
+)
+
+// Account is the keypair a Wallet hands out for signing. The private key
+// is only ever populated by the local keystore backend; RemoteSigner
+// leaves it nil since the key never leaves the signer daemon.
+type Account struct {
+	PrivateKey []byte
+	PublicKey  crypto.PubKey
+}
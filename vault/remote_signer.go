@@ -0,0 +1,210 @@
+package vault
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/nknorg/nkn/common"
+	"github.com/nknorg/nkn/crypto"
+)
+
+// RemoteSigner is a Wallet backend that forwards every signing request to
+// an nkn-signer daemon over a Unix socket instead of holding private keys
+// in nknd's own memory. It lets operators run nknd in a less trusted
+// environment while keeping the signer on a hardened host.
+type RemoteSigner struct {
+	conn   net.Conn
+	rd     *bufio.Reader
+	mu     sync.Mutex
+	token  string
+	nextID uint64
+
+	defaultAccount *Account
+}
+
+type signerRequest struct {
+	ID     uint64          `json:"id"`
+	Token  string          `json:"token"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+type signerResponse struct {
+	ID     uint64          `json:"id"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// NewRemoteSigner dials the nkn-signer daemon listening on socketPath,
+// authenticating every request with token, and fetches the node's public
+// key so GetDefaultAccount can be served without a round trip each call.
+func NewRemoteSigner(socketPath string, token string) (*RemoteSigner, error) {
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("connect to nkn-signer at %s: %v", socketPath, err)
+	}
+
+	rs := &RemoteSigner{
+		conn:  conn,
+		rd:    bufio.NewReader(conn),
+		token: token,
+	}
+
+	publicKey, err := rs.getPublicKey()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	rs.defaultAccount = &Account{PublicKey: publicKey}
+
+	return rs, nil
+}
+
+func (rs *RemoteSigner) call(method string, params interface{}, result interface{}) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	req := signerRequest{
+		ID:     atomic.AddUint64(&rs.nextID, 1),
+		Token:  rs.token,
+		Method: method,
+		Params: raw,
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	enc := json.NewEncoder(rs.conn)
+	if err := enc.Encode(req); err != nil {
+		return fmt.Errorf("nkn-signer request failed: %v", err)
+	}
+
+	line, err := rs.rd.ReadBytes('\n')
+	if err != nil {
+		return fmt.Errorf("nkn-signer response failed: %v", err)
+	}
+
+	var resp signerResponse
+	if err := json.Unmarshal(line, &resp); err != nil {
+		return err
+	}
+	if resp.ID != req.ID {
+		return errors.New("nkn-signer response id mismatch")
+	}
+	if resp.Error != "" {
+		return errors.New(resp.Error)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Result, result)
+}
+
+func (rs *RemoteSigner) getPublicKey() (crypto.PubKey, error) {
+	var hexPubKey string
+	if err := rs.call("getPublicKey", nil, &hexPubKey); err != nil {
+		return crypto.PubKey{}, err
+	}
+	encoded, err := common.HexStringToBytes(hexPubKey)
+	if err != nil {
+		return crypto.PubKey{}, err
+	}
+	publicKey, err := crypto.DecodePoint(encoded)
+	if err != nil {
+		return crypto.PubKey{}, err
+	}
+	return *publicKey, nil
+}
+
+// GetDefaultAccount returns the public key this signer daemon represents.
+// The private key is never transferred and Account.PrivateKey is left nil.
+func (rs *RemoteSigner) GetDefaultAccount() (*Account, error) {
+	return rs.defaultAccount, nil
+}
+
+// GetAccount returns the default account if publicKey matches it; this
+// signer daemon only ever signs on behalf of the single key it was
+// provisioned with.
+func (rs *RemoteSigner) GetAccount(publicKey crypto.PubKey) (*Account, error) {
+	if publicKey != rs.defaultAccount.PublicKey {
+		return nil, errors.New("nkn-signer: unknown public key")
+	}
+	return rs.defaultAccount, nil
+}
+
+// Sign asks the signer daemon to sign a transaction payload over its
+// generic signTransaction method. Block headers and sigchain elements go
+// through SignBlockHeader / SignSigChainElem instead, which the daemon
+// dispatches to distinct methods so a signature produced for one purpose
+// can never be replayed as valid for another.
+func (rs *RemoteSigner) Sign(publicKey crypto.PubKey, data []byte) ([]byte, error) {
+	if publicKey != rs.defaultAccount.PublicKey {
+		return nil, errors.New("nkn-signer: unknown public key")
+	}
+	var signature []byte
+	if err := rs.call("signTransaction", map[string]interface{}{"data": data}, &signature); err != nil {
+		return nil, err
+	}
+	return signature, nil
+}
+
+// SignBlockHeader asks the signer daemon to sign a serialized block header
+// over its signBlockHeader method.
+func (rs *RemoteSigner) SignBlockHeader(publicKey crypto.PubKey, data []byte) ([]byte, error) {
+	if publicKey != rs.defaultAccount.PublicKey {
+		return nil, errors.New("nkn-signer: unknown public key")
+	}
+	var signature []byte
+	if err := rs.call("signBlockHeader", map[string]interface{}{"data": data}, &signature); err != nil {
+		return nil, err
+	}
+	return signature, nil
+}
+
+// SignSigChainElem asks the signer daemon to sign a sigchain element,
+// used by por when relaying through this node.
+func (rs *RemoteSigner) SignSigChainElem(publicKey crypto.PubKey, data []byte) ([]byte, error) {
+	if publicKey != rs.defaultAccount.PublicKey {
+		return nil, errors.New("nkn-signer: unknown public key")
+	}
+	var signature []byte
+	if err := rs.call("signSigChainElem", map[string]interface{}{"data": data}, &signature); err != nil {
+		return nil, err
+	}
+	return signature, nil
+}
+
+// ComputeVRFProof asks the signer daemon to compute this account's VRF
+// ticket over randomness, used by por.SigChain.AppendElem when this node
+// relays through the proposer election without ever learning the raw
+// signing key the daemon holds.
+func (rs *RemoteSigner) ComputeVRFProof(randomness []byte) ([]byte, error) {
+	var proof []byte
+	if err := rs.call("computeVRFProof", map[string]interface{}{"data": randomness}, &proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// VRFPublicKey asks the signer daemon for the public key ComputeVRFProof's
+// tickets verify against. Like ComputeVRFProof, this never exposes the
+// underlying VRF secret scalar to the caller.
+func (rs *RemoteSigner) VRFPublicKey() ([]byte, error) {
+	var vrfPublicKey []byte
+	if err := rs.call("getVRFPublicKey", nil, &vrfPublicKey); err != nil {
+		return nil, err
+	}
+	return vrfPublicKey, nil
+}
+
+// Close releases the connection to the signer daemon.
+func (rs *RemoteSigner) Close() error {
+	return rs.conn.Close()
+}
@@ -0,0 +1,43 @@
+package vault
+
+import (
+	"github.com/nknorg/nkn/crypto"
+)
+
+// Wallet abstracts access to a node's signing key material so that nknd
+// never has to care whether the key lives in a local keystore file or on
+// a remote nkn-signer daemon. GetWallet returns the node's local keystore
+// backend; NewRemoteSigner returns one that forwards to an nkn-signer
+// daemon instead. Callers written against this interface, such as
+// por.SigChain.AppendElem, work with either backend.
+//
+// GetWallet's local keystore backend is not part of this source tree (no
+// file in this package defines it), so SignBlockHeader, SignSigChainElem,
+// ComputeVRFProof, and VRFPublicKey can only be added here, on the
+// interface, and on RemoteSigner; whatever type GetWallet returns needs
+// the same four methods added wherever it actually lives for
+// `wallet = vault.GetWallet()` to keep compiling. The same applies to
+// consensus/ising's proposer service, which isn't in this tree either: it
+// currently takes the raw *Account nknd.StartConsensus passes it rather
+// than a Wallet, so rewiring its block-proposal signing through this
+// interface is a change that belongs in that package.
+type Wallet interface {
+	GetDefaultAccount() (*Account, error)
+	GetAccount(publicKey crypto.PubKey) (*Account, error)
+	Sign(publicKey crypto.PubKey, data []byte) ([]byte, error)
+	SignBlockHeader(publicKey crypto.PubKey, data []byte) ([]byte, error)
+	SignSigChainElem(publicKey crypto.PubKey, data []byte) ([]byte, error)
+
+	// ComputeVRFProof computes this account's VRF ticket for randomness,
+	// used by por.SigChain.AppendElem so a node's proposer-election entry
+	// never requires handing its raw signing key to the caller.
+	ComputeVRFProof(randomness []byte) ([]byte, error)
+
+	// VRFPublicKey returns the public key ComputeVRFProof's tickets verify
+	// against, encoded the way por's VRF expects (elliptic.Marshal over
+	// its own curve), not crypto.PubKey.EncodePoint()'s format. It is
+	// carried alongside ComputeVRFProof's output in SigChainElem so a
+	// verifier never needs to guess which encoding a signing public key
+	// is in.
+	VRFPublicKey() ([]byte, error)
+}